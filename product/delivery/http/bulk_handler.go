@@ -0,0 +1,141 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/iqdf/benjerry-service/domain"
+)
+
+// bulkItemResult reports the outcome of a single row of a bulk request.
+// handleBulkCreateProducts and handleBulkUpdateProducts build a bulkResponse
+// of these instead of aborting the whole call on the first invalid row, so
+// importers can tell which rows of a large catalog upload need fixing.
+type bulkItemResult struct {
+	Index     int    `json:"index"`
+	ProductID string `json:"productId,omitempty"`
+	Status    int    `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+// bulkResponse is the body of a 207 Multi-Status bulk response.
+type bulkResponse struct {
+	Results []bulkItemResult `json:"results"`
+}
+
+// handleBulkCreateProducts provides handler func that creates many products
+// in a single call.
+// [POST] /api/products/bulk
+func (handler *ProductHandler) handleBulkCreateProducts() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var rows []json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&rows); err != nil {
+			writeErrorMessage(w, newRequestAPIError(r, err))
+			return
+		}
+
+		results := make([]bulkItemResult, len(rows))
+		products := make([]domain.Product, 0, len(rows))
+		rowOfProduct := make([]int, 0, len(rows))
+
+		for i, row := range rows {
+			var item productCreateRequest
+			if err := json.Unmarshal(row, &item); err != nil {
+				results[i] = bulkItemResult{Index: i, Status: http.StatusBadRequest, Message: err.Error()}
+				continue
+			}
+			if err := validateAgainstSchema("productCreateRequest", row); err != nil {
+				results[i] = bulkItemResult{Index: i, ProductID: item.ProductId, Status: http.StatusBadRequest, Message: err.Error()}
+				continue
+			}
+			products = append(products, createToProduct(item))
+			rowOfProduct = append(rowOfProduct, i)
+		}
+
+		if len(products) > 0 {
+			outcomes, err := handler.service.CreateProducts(r.Context(), products)
+			if err != nil {
+				writeErrorMessage(w, newDomainAPIError(r, err))
+				return
+			}
+			for j, outcome := range outcomes {
+				i := rowOfProduct[j]
+				results[i] = bulkItemResult{Index: i, ProductID: products[j].ProductID, Status: statusForError(outcome)}
+				if outcome == nil {
+					results[i].Status = http.StatusCreated
+				} else {
+					results[i].Message = outcome.Error()
+				}
+			}
+		}
+
+		w.WriteHeader(http.StatusMultiStatus)
+		json.NewEncoder(w).Encode(bulkResponse{Results: results})
+	}
+}
+
+// handleBulkUpdateProducts provides handler func that updates many products
+// in a single call.
+// [PATCH] /api/products/bulk
+func (handler *ProductHandler) handleBulkUpdateProducts() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var rows []json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&rows); err != nil {
+			writeErrorMessage(w, newRequestAPIError(r, err))
+			return
+		}
+
+		results := make([]bulkItemResult, len(rows))
+		productIDs := make([]string, 0, len(rows))
+		products := make([]domain.Product, 0, len(rows))
+		rowOfProduct := make([]int, 0, len(rows))
+
+		for i, row := range rows {
+			var envelope struct {
+				ProductID string `json:"productId"`
+			}
+			if err := json.Unmarshal(row, &envelope); err != nil || envelope.ProductID == "" {
+				results[i] = bulkItemResult{Index: i, Status: http.StatusBadRequest, Message: "row is missing productId"}
+				continue
+			}
+
+			var item productUpdateRequest
+			if err := json.Unmarshal(row, &item); err != nil {
+				results[i] = bulkItemResult{Index: i, ProductID: envelope.ProductID, Status: http.StatusBadRequest, Message: err.Error()}
+				continue
+			}
+			if err := validateAgainstSchema("productUpdateRequest", row); err != nil {
+				results[i] = bulkItemResult{Index: i, ProductID: envelope.ProductID, Status: http.StatusBadRequest, Message: err.Error()}
+				continue
+			}
+
+			product := updateToProduct(item)
+			product.ProductID = envelope.ProductID
+			products = append(products, product)
+			productIDs = append(productIDs, envelope.ProductID)
+			rowOfProduct = append(rowOfProduct, i)
+		}
+
+		if len(products) > 0 {
+			outcomes, err := handler.service.UpdateProducts(r.Context(), productIDs, products)
+			if err != nil {
+				writeErrorMessage(w, newDomainAPIError(r, err))
+				return
+			}
+			for j, outcome := range outcomes {
+				i := rowOfProduct[j]
+				results[i] = bulkItemResult{Index: i, ProductID: productIDs[j], Status: statusForError(outcome)}
+				if outcome != nil {
+					results[i].Message = outcome.Error()
+				}
+			}
+		}
+
+		w.WriteHeader(http.StatusMultiStatus)
+		json.NewEncoder(w).Encode(bulkResponse{Results: results})
+	}
+}
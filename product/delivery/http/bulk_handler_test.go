@@ -0,0 +1,81 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iqdf/benjerry-service/domain"
+)
+
+// fakeBulkProductService implements just enough of domain.ProductService for
+// the bulk handler tests; everything else is left to the embedded nil
+// interface so a call into an unexercised method panics loudly instead of
+// silently doing nothing.
+type fakeBulkProductService struct {
+	domain.ProductService
+	createOutcome func(domain.Product) error
+}
+
+func (f *fakeBulkProductService) CreateProducts(ctx context.Context, products []domain.Product) ([]error, error) {
+	outcomes := make([]error, len(products))
+	for i, product := range products {
+		outcomes[i] = f.createOutcome(product)
+	}
+	return outcomes, nil
+}
+
+// TestHandleBulkCreateProductsPreservesOrderWithRejectedRow asserts that a
+// malformed row in the middle of the batch doesn't shift the rows around
+// it: the rowOfProduct index remapping in handleBulkCreateProducts is the
+// riskiest part of this handler and silently breaking it wouldn't fail loud.
+func TestHandleBulkCreateProductsPreservesOrderWithRejectedRow(t *testing.T) {
+	// Row 1 is syntactically valid JSON (so the outer array still decodes)
+	// but fails to unmarshal into productCreateRequest: productId is a
+	// number where a string is expected.
+	body := `[
+		{"productId":"111","name":"Chunky Monkey","description":"banana ice cream","allergy_info":"walnuts","dietary_certifications":"none"},
+		{"productId":123,"name":"Broken Row"},
+		{"productId":"333","name":"Cherry Garcia","description":"cherry ice cream","allergy_info":"none","dietary_certifications":"none"}
+	]`
+
+	service := &fakeBulkProductService{
+		createOutcome: func(product domain.Product) error {
+			if product.ProductID == "333" {
+				return domain.ErrConflict
+			}
+			return nil
+		},
+	}
+	handler := NewProductHandler(service, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/bulk", bytes.NewReader([]byte(body)))
+	rec := httptest.NewRecorder()
+
+	handler.handleBulkCreateProducts()(rec, req)
+
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusMultiStatus)
+	}
+
+	var resp bulkResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(resp.Results))
+	}
+
+	if got := resp.Results[0]; got.Index != 0 || got.ProductID != "111" || got.Status != http.StatusCreated {
+		t.Errorf("row 0 = %+v, want created product 111 at index 0", got)
+	}
+	if got := resp.Results[1]; got.Index != 1 || got.Status != http.StatusBadRequest {
+		t.Errorf("row 1 = %+v, want a bad-request outcome at index 1 for the malformed row", got)
+	}
+	if got := resp.Results[2]; got.Index != 2 || got.ProductID != "333" || got.Status != http.StatusConflict {
+		t.Errorf("row 2 = %+v, want conflict for product 333 at index 2", got)
+	}
+}
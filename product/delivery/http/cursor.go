@@ -0,0 +1,26 @@
+package http
+
+import "encoding/base64"
+
+// encodeCursor wraps an opaque pagination token from domain.ProductService
+// so it round-trips safely through a URL query parameter.
+func encodeCursor(token string) string {
+	if token == "" {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+// decodeCursor reverses encodeCursor. An invalid cursor is treated as "no
+// cursor" (start from the beginning) rather than a hard error, since a
+// corrupted bookmark shouldn't break pagination for the client.
+func decodeCursor(cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+	token, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ""
+	}
+	return string(token)
+}
@@ -0,0 +1,30 @@
+package http
+
+import "testing"
+
+func TestCursorRoundTrip(t *testing.T) {
+	cases := []string{
+		"",
+		"product:00000123",
+		"a-token-with-special-chars/+=",
+	}
+
+	for _, token := range cases {
+		encoded := encodeCursor(token)
+		if decoded := decodeCursor(encoded); decoded != token {
+			t.Errorf("decodeCursor(encodeCursor(%q)) = %q, want %q", token, decoded, token)
+		}
+	}
+}
+
+func TestEncodeCursorEmpty(t *testing.T) {
+	if got := encodeCursor(""); got != "" {
+		t.Errorf("encodeCursor(\"\") = %q, want empty string", got)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	if got := decodeCursor("not valid base64!!"); got != "" {
+		t.Errorf("decodeCursor(invalid) = %q, want empty string (treated as no cursor)", got)
+	}
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/iqdf/benjerry-service/domain"
+)
+
+// problemContentType is the media type for RFC 7807 error responses.
+const problemContentType = "application/problem+json"
+
+// FieldError is one field-level validation failure reported alongside an
+// APIError.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// APIError is an RFC 7807 (https://tools.ietf.org/html/rfc7807)
+// application/problem+json error response. Type is a stable,
+// machine-readable error code (e.g. "product.not_found") so clients can
+// branch on it instead of string-matching Detail.
+type APIError struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	return e.Detail
+}
+
+// problem is the Type/Title/Status an APIError takes for a given domain
+// error sentinel.
+type problem struct {
+	errorType string
+	title     string
+	status    int
+}
+
+// problemTypeUnknown is used for any error domain.ProductService returns
+// that problemRegistry doesn't recognize.
+const problemTypeUnknown = "about:blank"
+
+// problemRegistry maps domain error sentinels to their problem+json shape.
+// It replaces the old getResponseStatus switch: clients key
+// off Type instead of the error message.
+var problemRegistry = map[error]problem{
+	domain.ErrAuthFail:         {errorType: "auth.invalid_credentials", title: "Invalid credentials", status: http.StatusUnauthorized},
+	domain.ErrExpiredToken:     {errorType: "auth.expired_token", title: "Token expired", status: http.StatusUnauthorized},
+	domain.ErrBadParamInput:    {errorType: "product.bad_param", title: "Invalid request parameters", status: http.StatusBadRequest},
+	domain.ErrConflict:         {errorType: "product.conflict", title: "Product already exists", status: http.StatusConflict},
+	domain.ErrResourceNotFound: {errorType: "product.not_found", title: "Product not found", status: http.StatusNotFound},
+}
+
+// statusForError maps a domain.ProductService error to an HTTP status using
+// problemRegistry, for call sites that report a status without a full
+// problem+json body, such as one row of a bulk response.
+func statusForError(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if p, ok := problemRegistry[err]; ok {
+		return p.status
+	}
+	return http.StatusInternalServerError
+}
+
+// newDomainAPIError builds the APIError for an error returned by
+// domain.ProductService, falling back to a generic 500 for anything not in
+// problemRegistry.
+func newDomainAPIError(r *http.Request, err error) *APIError {
+	p, ok := problemRegistry[err]
+	if !ok {
+		p = problem{errorType: problemTypeUnknown, title: "Internal Server Error", status: http.StatusInternalServerError}
+	}
+	return &APIError{
+		Type:     p.errorType,
+		Title:    p.title,
+		Status:   p.status,
+		Detail:   err.Error(),
+		Instance: r.URL.Path,
+	}
+}
+
+// newRequestAPIError builds a 400 APIError for a malformed request, e.g. a
+// body that failed to decode, as opposed to a domain.ProductService failure.
+func newRequestAPIError(r *http.Request, err error, fieldErrs ...FieldError) *APIError {
+	return &APIError{
+		Type:     "request.invalid_body",
+		Title:    "Invalid request body",
+		Status:   http.StatusBadRequest,
+		Detail:   err.Error(),
+		Instance: r.URL.Path,
+		Errors:   fieldErrs,
+	}
+}
+
+// fieldErrorsFromDecodeErr extracts a FieldError out of a
+// json.UnmarshalTypeError, the one encoding/json decode error that
+// identifies an offending field, so a type mismatch can be reported as
+// structured detail instead of just a decode message.
+func fieldErrorsFromDecodeErr(err error) []FieldError {
+	typeErr, ok := err.(*json.UnmarshalTypeError)
+	if !ok {
+		return nil
+	}
+	return []FieldError{{Field: typeErr.Field, Reason: "expected " + typeErr.Type.String()}}
+}
+
+// writeErrorMessage writes apiErr as application/problem+json.
+func writeErrorMessage(w http.ResponseWriter, apiErr *APIError) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(apiErr.Status)
+	json.NewEncoder(w).Encode(apiErr)
+}
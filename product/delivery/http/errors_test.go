@@ -0,0 +1,75 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iqdf/benjerry-service/domain"
+)
+
+var errUnregistered = errors.New("some unmapped service failure")
+
+// TestStatusForErrorMapsRegisteredSentinels asserts every sentinel in
+// problemRegistry maps to its intended status, since statusForError and
+// newDomainAPIError both key off it and a wrong entry silently misreports a
+// whole class of response.
+func TestStatusForErrorMapsRegisteredSentinels(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+	}{
+		{domain.ErrAuthFail, http.StatusUnauthorized},
+		{domain.ErrExpiredToken, http.StatusUnauthorized},
+		{domain.ErrBadParamInput, http.StatusBadRequest},
+		{domain.ErrConflict, http.StatusConflict},
+		{domain.ErrResourceNotFound, http.StatusNotFound},
+	}
+	for _, c := range cases {
+		if got := statusForError(c.err); got != c.status {
+			t.Errorf("statusForError(%v) = %d, want %d", c.err, got, c.status)
+		}
+	}
+}
+
+// TestNewDomainAPIErrorMapsRegisteredSentinels asserts newDomainAPIError
+// builds an APIError with the Type/Status problemRegistry declares for each
+// registered sentinel, and falls back to a generic 500 for anything else.
+func TestNewDomainAPIErrorMapsRegisteredSentinels(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/111", nil)
+
+	cases := []struct {
+		err        error
+		wantType   string
+		wantStatus int
+	}{
+		{domain.ErrAuthFail, "auth.invalid_credentials", http.StatusUnauthorized},
+		{domain.ErrExpiredToken, "auth.expired_token", http.StatusUnauthorized},
+		{domain.ErrBadParamInput, "product.bad_param", http.StatusBadRequest},
+		{domain.ErrConflict, "product.conflict", http.StatusConflict},
+		{domain.ErrResourceNotFound, "product.not_found", http.StatusNotFound},
+	}
+	for _, c := range cases {
+		apiErr := newDomainAPIError(r, c.err)
+		if apiErr.Type != c.wantType || apiErr.Status != c.wantStatus {
+			t.Errorf("newDomainAPIError(%v) = {Type: %q, Status: %d}, want {%q, %d}",
+				c.err, apiErr.Type, apiErr.Status, c.wantType, c.wantStatus)
+		}
+	}
+}
+
+// TestNewDomainAPIErrorUnknownFallsBackTo500 asserts an error not present in
+// problemRegistry still produces a well-formed APIError instead of a zero
+// value or a panic.
+func TestNewDomainAPIErrorUnknownFallsBackTo500(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/111", nil)
+
+	apiErr := newDomainAPIError(r, errUnregistered)
+	if apiErr.Status != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", apiErr.Status, http.StatusInternalServerError)
+	}
+	if apiErr.Type != problemTypeUnknown {
+		t.Errorf("type = %q, want %q", apiErr.Type, problemTypeUnknown)
+	}
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/iqdf/benjerry-service/domain"
+)
+
+const defaultProductListLimit = 20
+
+// ListProducts provides handler func that lists and searches products.
+// [GET] /api/products/?limit=&cursor=&sourcing_value=&dietary=&q=
+func (handler *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request, params ListProductsParams) {
+	w.Header().Set("Content-Type", "application/json")
+
+	limit := defaultProductListLimit
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+	}
+
+	opts := domain.ListOptions{
+		Limit:  limit,
+		Cursor: decodeCursor(stringValue(params.Cursor)),
+		Query:  stringValue(params.Q),
+	}
+	if params.SourcingValue != nil {
+		opts.SourcingValue = *params.SourcingValue
+	}
+	if params.Dietary != nil {
+		opts.DietaryCertification = *params.Dietary
+	}
+
+	page, err := handler.service.ListProducts(r.Context(), opts)
+	if err != nil {
+		writeErrorMessage(w, newDomainAPIError(r, err))
+		return
+	}
+
+	data := make([]productResponseData, len(page.Items))
+	for i, product := range page.Items {
+		data[i] = *newSingleResponse(product).Product
+	}
+
+	json.NewEncoder(w).Encode(productListResponse{
+		Data:       data,
+		NextCursor: encodeCursor(page.NextCursor),
+		Total:      page.Total,
+	})
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
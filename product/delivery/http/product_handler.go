@@ -3,255 +3,285 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/justinas/alice"
 
-	validatorLib "github.com/iqdf/benjerry-service/common/validator"
 	"github.com/iqdf/benjerry-service/domain"
+	"github.com/iqdf/benjerry-service/product/delivery/http/render"
 )
 
-// productSingleResponse ...
-type productSingleResponse struct {
-	Data productResponseData `json:"product"`
-}
-
-type productResponseData struct {
-	ProductID            string    `json:"productId"`
-	Name                 string    `json:"name"`
-	ImageClosedURL       string    `json:"image_closed"`
-	ImageOpenURL         string    `json:"image_open"`
-	Description          string    `json:"description"`
-	Story                string    `json:"story"`
-	SourcingValues       *[]string `json:"sourcing_values,omitempty"`
-	Ingredients          *[]string `json:"ingredients,omitempty"`
-	AllergyInfo          string    `json:"allergy_info"`
-	DietaryCertification string    `json:"dietary_certifications"`
-}
-
-// messageError ....
-type messageError struct {
-	Message string `json:"message"`
-}
-
-type productCreateRequest struct {
-	ProductID            string    `json:"productId" validate:"required,numeric,min=3"`
-	Name                 string    `json:"name" validate:"required,ascii,max=50"`
-	ImageClosedURL       string    `json:"image_closed" validate:"omitempty,uri"`
-	ImageOpenURL         string    `json:"image_open" validate:"omitempty,uri"`
-	Description          string    `json:"description" validate:"required,max=100"`
-	Story                string    `json:"story" validate:"omitempty,max=300"`
-	SourcingValues       *[]string `json:"sourcing_values"`
-	Ingredients          *[]string `json:"ingredients"`
-	AllergyInfo          string    `json:"allergy_info" validate:"required,max=50"`
-	DietaryCertification string    `json:"dietary_certifications" validate:"required,max=25"`
-}
-
-type productUpdateRequest struct {
-	Name                 string    `json:"name" validate:"omitempty,ascii,max=50"`
-	ImageClosedURL       string    `json:"image_closed" validate:"omitempty,uri"`
-	ImageOpenURL         string    `json:"image_open" validate:"omitempty,uri"`
-	Description          string    `json:"description" validate:"omitempty,max=100"`
-	Story                string    `json:"story" validate:"omitempty,max=300"`
-	SourcingValues       *[]string `json:"sourcing_values" validate:"omitempty"`
-	Ingredients          *[]string `json:"ingredients" validate:"omitempty"`
-	AllergyInfo          string    `json:"allergy_info" validate:"omitempty,max=50"`
-	DietaryCertification string    `json:"dietary_certifications" validate:"omitempty,max=25"`
-}
-
 func createToProduct(requestData productCreateRequest) domain.Product {
 	return domain.Product{
-		ProductID:            requestData.ProductID,
+		ProductID:            requestData.ProductId,
 		Name:                 requestData.Name,
-		ImageClosedURL:       requestData.ImageClosedURL,
-		ImageOpenURL:         requestData.ImageOpenURL,
+		ImageClosedURL:       requestData.ImageClosed,
+		ImageOpenURL:         requestData.ImageOpen,
 		Description:          requestData.Description,
 		Story:                requestData.Story,
 		SourcingValues:       requestData.SourcingValues,
 		Ingredients:          requestData.Ingredients,
 		AllergyInfo:          requestData.AllergyInfo,
-		DietaryCertification: requestData.DietaryCertification,
+		DietaryCertification: requestData.DietaryCertifications,
 	}
 }
 
 func updateToProduct(requestData productUpdateRequest) domain.Product {
 	return domain.Product{
 		Name:                 requestData.Name,
-		ImageClosedURL:       requestData.ImageClosedURL,
-		ImageOpenURL:         requestData.ImageOpenURL,
+		ImageClosedURL:       requestData.ImageClosed,
+		ImageOpenURL:         requestData.ImageOpen,
 		Description:          requestData.Description,
 		Story:                requestData.Story,
 		SourcingValues:       requestData.SourcingValues,
 		Ingredients:          requestData.Ingredients,
 		AllergyInfo:          requestData.AllergyInfo,
-		DietaryCertification: requestData.DietaryCertification,
+		DietaryCertification: requestData.DietaryCertifications,
 	}
 }
 
-// ProductHandler ...
+// ProductHandler implements the generated ServerInterface, so openapi.yaml
+// is the single source of truth for request/response shape and validation.
 type ProductHandler struct {
-	service domain.ProductService
+	service  domain.ProductService
+	renderer render.Renderer
 }
 
 // NewProductHandler creates new HTTP handler
 // for product related request
-func NewProductHandler(service domain.ProductService) *ProductHandler {
+func NewProductHandler(service domain.ProductService, renderer render.Renderer) *ProductHandler {
 	handler := &ProductHandler{
-		service: service,
+		service:  service,
+		renderer: renderer,
 	}
 	return handler
 }
 
+// productPageData is the template binding for the single-product HTML page.
+type productPageData struct {
+	Title       string
+	Description string
+	Product     *productResponseData
+}
+
+// errorPageData is the template binding for the HTML error page. It shares
+// layout.html with productPageData, so it needs the same Title/Description
+// fields the layout renders into <title>/<meta name="description">.
+type errorPageData struct {
+	Status      int
+	Title       string
+	Description string
+	Detail      string
+}
+
+// acceptsHTML reports whether text/html is the most preferred media type in
+// the request's Accept header, e.g. a browser or search-engine crawler
+// hitting the base route directly instead of the .html variant. An API
+// client sending "application/json;q=0.9, text/html;q=0.1" as a fallback
+// still gets JSON, and a missing or "*/*" header is treated as JSON-only.
+func acceptsHTML(r *http.Request) bool {
+	best, bestQ := "", -1.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, q := parseAcceptPart(part)
+		if mediaType == "" {
+			continue
+		}
+		if q > bestQ {
+			best, bestQ = mediaType, q
+		}
+	}
+	return best == "text/html"
+}
+
+// parseAcceptPart splits a single comma-separated segment of an Accept
+// header (e.g. "text/html;q=0.1") into its media type and quality value,
+// defaulting q to 1 when absent.
+func parseAcceptPart(part string) (mediaType string, q float64) {
+	fields := strings.Split(part, ";")
+	mediaType = strings.TrimSpace(fields[0])
+	q = 1.0
+	for _, param := range fields[1:] {
+		param = strings.TrimSpace(param)
+		value, ok := strings.CutPrefix(param, "q=")
+		if !ok {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			q = parsed
+		}
+	}
+	return mediaType, q
+}
+
 func newSingleResponse(product domain.Product) productSingleResponse {
-	productData := productResponseData{
-		ProductID:            product.ProductID,
-		Name:                 product.Name,
-		ImageClosedURL:       product.ImageClosedURL,
-		ImageOpenURL:         product.ImageOpenURL,
-		Description:          product.Description,
-		Story:                product.Story,
-		SourcingValues:       product.SourcingValues,
-		Ingredients:          product.Ingredients,
-		AllergyInfo:          product.AllergyInfo,
-		DietaryCertification: product.DietaryCertification,
+	productData := &productResponseData{
+		ProductId:             product.ProductID,
+		Name:                  product.Name,
+		ImageClosed:           product.ImageClosedURL,
+		ImageOpen:             product.ImageOpenURL,
+		Description:           product.Description,
+		Story:                 product.Story,
+		SourcingValues:        product.SourcingValues,
+		Ingredients:           product.Ingredients,
+		AllergyInfo:           product.AllergyInfo,
+		DietaryCertifications: product.DietaryCertification,
 	}
-	return productSingleResponse{Data: productData}
+	return productSingleResponse{Product: productData}
 }
 
 // Routes register handle func with the path url
 func (handler *ProductHandler) Routes(router *mux.Router, middleware alice.Chain) {
-	// Register middleware here
-	getHandler := middleware.Then(handler.handleGetProduct())
-	updateHandler := middleware.Then(handler.handleUpdateProduct())
-	deleteHandler := middleware.Then(handler.handleDeleteProduct())
-	createHandler := middleware.Then(handler.handleCreateProduct())
+	// Validate every request against openapi.yaml before it reaches a
+	// handler method, in place of the old per-handler decode+validate calls.
+	validated := middleware.Append(newRequestValidator())
+	wrapper := &ServerInterfaceWrapper{Handler: handler}
+
+	getHandler := validated.ThenFunc(wrapper.GetProduct)
+	updateHandler := validated.ThenFunc(wrapper.UpdateProduct)
+	deleteHandler := validated.ThenFunc(wrapper.DeleteProduct)
+	createHandler := validated.ThenFunc(wrapper.CreateProduct)
+	listHandler := validated.ThenFunc(wrapper.ListProducts)
+
+	// The .html variant isn't part of openapi.yaml, so it bypasses the
+	// schema validator and always renders HTML regardless of Accept.
+	getHTMLHandler := middleware.ThenFunc(handler.handleGetProductHTML())
+
+	// Bulk rows are validated one at a time against the same schemas so a
+	// bad row can be reported instead of rejecting the whole call, which
+	// the whole-request validator above can't do; it only runs on the
+	// routes openapi.yaml models.
+	bulkCreateHandler := middleware.ThenFunc(handler.handleBulkCreateProducts())
+	bulkUpdateHandler := middleware.ThenFunc(handler.handleBulkUpdateProducts())
 
 	// Register handler methods to router here...
+	// "/bulk" and "/{product_id}.html" are registered before "/{product_id}"
+	// so neither is swallowed by the product_id wildcard, which otherwise
+	// matches any single path segment, "123.html" included.
+	router.Handle("/bulk", bulkCreateHandler).Methods("POST").Name("PRODUCT_BULK_CREATE")
+	router.Handle("/bulk", bulkUpdateHandler).Methods("PATCH").Name("PRODUCT_BULK_UPDATE")
+	router.Handle("/{product_id}.html", getHTMLHandler).Methods("GET").Name("PRODUCT_GET_HTML")
 	router.Handle("/{product_id}", getHandler).Methods("GET").Name("PRODUCT_GET")
 	router.Handle("/{product_id}", updateHandler).Methods("PUT").Name("PRODUCT_UPDATE")
 	router.Handle("/{product_id}", deleteHandler).Methods("DELETE").Name("PRODUCT_DELETE")
 	router.Handle("/", createHandler).Methods("POST").Name("PRODUCT_CREATE")
+	router.Handle("/", listHandler).Methods("GET").Name("PRODUCT_LIST")
 }
 
-// handleGetProduct provides handler func that gets a product
+// GetProduct provides handler func that gets a product
 // [GET] /api/products/:product_id
-func (handler *ProductHandler) handleGetProduct() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Add("Content-Type", "application/json")
-
-		params := mux.Vars(r)
-		productID := params["product_id"]
-
-		product, err := handler.service.GetProduct(r.Context(), productID)
-
-		if err != nil {
-			status := getResponseStatus(err)
-			writeErrorMessage(w, err.Error(), status)
-			return
-		}
+//
+// It negotiates the response format from the Accept header: browsers and
+// crawlers requesting text/html get a rendered product page, everyone else
+// gets the JSON representation.
+func (handler *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request, productID string) {
+	handler.respondProduct(w, r, productID, acceptsHTML(r))
+}
 
-		response := newSingleResponse(product)
-		json.NewEncoder(w).Encode(response)
+// handleGetProductHTML always renders the HTML product page, regardless of
+// Accept, for the dedicated /{product_id}.html route.
+// [GET] /api/products/:product_id.html
+func (handler *ProductHandler) handleGetProductHTML() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		productID := mux.Vars(r)["product_id"]
+		handler.respondProduct(w, r, productID, true)
 	}
 }
 
-// handleCreateProduct provides handler func that creates a product
-// [POST] /api/product/
-func (handler *ProductHandler) handleCreateProduct() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
+func (handler *ProductHandler) respondProduct(w http.ResponseWriter, r *http.Request, productID string, wantHTML bool) {
+	product, err := handler.service.GetProduct(r.Context(), productID)
 
-		var productCreate productCreateRequest
-		if err := validatorLib.DecodeAndValidateJSON(r.Body, &productCreate); err != nil {
-			verr, _ := err.(*validatorLib.ValidationError)
-			writeErrorMessage(w, verr.Message(), http.StatusBadRequest)
+	if err != nil {
+		apiErr := newDomainAPIError(r, err)
+		if wantHTML {
+			handler.renderError(w, apiErr.Status, apiErr.Detail)
 			return
 		}
+		writeErrorMessage(w, apiErr)
+		return
+	}
 
-		var product = createToProduct(productCreate)
-		err := handler.service.CreateProduct(r.Context(), product)
+	response := newSingleResponse(product)
 
-		if err != nil {
-			status := getResponseStatus(err)
-			writeErrorMessage(w, err.Error(), status)
-			return
+	if wantHTML {
+		page := productPageData{
+			Title:       response.Product.Name,
+			Description: response.Product.Description,
+			Product:     response.Product,
 		}
-		w.WriteHeader(http.StatusCreated)
+		handler.renderer.HTML(w, http.StatusOK, "product", page)
+		return
 	}
+
+	w.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
 }
 
-// handleUpdateProduct provides handler func that updates a product
-// [PUT] /api/product/:product_id
-func (handler *ProductHandler) handleUpdateProduct() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
+// renderError renders the HTML error page for HTML-negotiated requests.
+func (handler *ProductHandler) renderError(w http.ResponseWriter, status int, detail string) {
+	handler.renderer.HTML(w, status, "error", errorPageData{
+		Status:      status,
+		Title:       http.StatusText(status),
+		Description: detail,
+		Detail:      detail,
+	})
+}
 
-		params := mux.Vars(r)
-		productID := params["product_id"]
+// CreateProduct provides handler func that creates a product
+// [POST] /api/products/
+func (handler *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
 
-		var productUpdate productUpdateRequest
-		if err := validatorLib.ValidateJSON(r.Body, &productUpdate); err != nil {
-			verr, _ := err.(*validatorLib.ValidationError)
-			writeErrorMessage(w, verr.Message(), http.StatusBadRequest)
-			return
-		}
-
-		var product = updateToProduct(productUpdate)
-		product.ProductID = productID
+	var productCreate productCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&productCreate); err != nil {
+		writeErrorMessage(w, newRequestAPIError(r, err, fieldErrorsFromDecodeErr(err)...))
+		return
+	}
 
-		err := handler.service.UpdateProduct(r.Context(), productID, product)
+	var product = createToProduct(productCreate)
+	err := handler.service.CreateProduct(r.Context(), product)
 
-		if err != nil {
-			status := getResponseStatus(err)
-			writeErrorMessage(w, err.Error(), status)
-			return
-		}
-		w.WriteHeader(http.StatusOK)
+	if err != nil {
+		writeErrorMessage(w, newDomainAPIError(r, err))
+		return
 	}
+	w.WriteHeader(http.StatusCreated)
 }
 
-// handleDeleteProduct provides handler func that deletes a product
-// [DEL] /api/product/:product_id
-func (handler *ProductHandler) handleDeleteProduct() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("content-type", "application/json")
+// UpdateProduct provides handler func that updates a product
+// [PUT] /api/products/:product_id
+func (handler *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request, productID string) {
+	w.Header().Set("Content-Type", "application/json")
 
-		params := mux.Vars(r)
-		productID := params["product_id"]
+	var productUpdate productUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&productUpdate); err != nil {
+		writeErrorMessage(w, newRequestAPIError(r, err, fieldErrorsFromDecodeErr(err)...))
+		return
+	}
 
-		err := handler.service.DeleteProduct(r.Context(), productID)
+	var product = updateToProduct(productUpdate)
+	product.ProductID = productID
 
-		if err != nil {
-			status := getResponseStatus(err)
-			writeErrorMessage(w, err.Error(), status)
-			return
-		}
-		w.WriteHeader(http.StatusOK)
+	err := handler.service.UpdateProduct(r.Context(), productID, product)
+
+	if err != nil {
+		writeErrorMessage(w, newDomainAPIError(r, err))
+		return
 	}
+	w.WriteHeader(http.StatusOK)
 }
 
-// writerErrorMessage is a helper that writes error message to response
-func writeErrorMessage(writer http.ResponseWriter, errMsg string, httpStatus int) {
-	writer.WriteHeader(httpStatus)
-	json.NewEncoder(writer).
-		Encode(messageError{Message: errMsg})
-}
+// DeleteProduct provides handler func that deletes a product
+// [DEL] /api/products/:product_id
+func (handler *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request, productID string) {
+	w.Header().Set("content-type", "application/json")
+
+	err := handler.service.DeleteProduct(r.Context(), productID)
 
-// getResponseStatus inputs error from application
-// and infers the appropriate HTTP status to be returned
-func getResponseStatus(err error) int {
-	switch err {
-	case nil:
-		return http.StatusOK
-	case domain.ErrAuthFail, domain.ErrExpiredToken:
-		return http.StatusUnauthorized
-	case domain.ErrBadParamInput:
-		return http.StatusBadRequest
-	case domain.ErrConflict:
-		return http.StatusOK
-	case domain.ErrResourceNotFound:
-		return http.StatusNotFound
-	default:
-		return http.StatusInternalServerError
+	if err != nil {
+		writeErrorMessage(w, newDomainAPIError(r, err))
+		return
 	}
+	w.WriteHeader(http.StatusOK)
 }
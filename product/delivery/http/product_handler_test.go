@@ -0,0 +1,159 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/iqdf/benjerry-service/domain"
+)
+
+// fakeGetProductService implements just enough of domain.ProductService for
+// the content-negotiation tests below.
+type fakeGetProductService struct {
+	domain.ProductService
+	product domain.Product
+	err     error
+}
+
+func (f *fakeGetProductService) GetProduct(ctx context.Context, productID string) (domain.Product, error) {
+	return f.product, f.err
+}
+
+// fakeRenderer is a render.Renderer that records its last call instead of
+// executing a real template, so handler tests don't need a template directory.
+type fakeRenderer struct {
+	status  int
+	name    string
+	binding interface{}
+}
+
+func (f *fakeRenderer) HTML(w io.Writer, status int, name string, binding interface{}) error {
+	f.status, f.name, f.binding = status, name, binding
+	return nil
+}
+
+// TestRespondProductJSON asserts that an Accept: application/json request
+// gets the JSON representation rather than a rendered page.
+func TestRespondProductJSON(t *testing.T) {
+	service := &fakeGetProductService{product: domain.Product{ProductID: "111", Name: "Chunky Monkey"}}
+	handler := NewProductHandler(service, &fakeRenderer{})
+
+	req := httptest.NewRequest(http.MethodGet, "/111", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+
+	handler.GetProduct(rec, req, "111")
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var resp productSingleResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Product.ProductId != "111" {
+		t.Errorf("product id = %q, want 111", resp.Product.ProductId)
+	}
+}
+
+// TestRespondProductHTML asserts that an Accept: text/html request renders
+// the product page with Title/Description populated, as layout.html needs.
+func TestRespondProductHTML(t *testing.T) {
+	service := &fakeGetProductService{product: domain.Product{ProductID: "111", Name: "Chunky Monkey", Description: "banana ice cream"}}
+	renderer := &fakeRenderer{}
+	handler := NewProductHandler(service, renderer)
+
+	req := httptest.NewRequest(http.MethodGet, "/111", nil)
+	req.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+
+	handler.GetProduct(rec, req, "111")
+
+	if renderer.name != "product" {
+		t.Fatalf("rendered template = %q, want %q", renderer.name, "product")
+	}
+	page, ok := renderer.binding.(productPageData)
+	if !ok {
+		t.Fatalf("binding type = %T, want productPageData", renderer.binding)
+	}
+	if page.Title != "Chunky Monkey" || page.Description != "banana ice cream" {
+		t.Errorf("page = %+v, want Title/Description populated from the product", page)
+	}
+}
+
+// TestRespondProductHTMLNotFound asserts that a domain error still renders
+// the HTML error page, with Description populated, for an HTML-negotiated
+// request instead of falling back to a problem+json body.
+func TestRespondProductHTMLNotFound(t *testing.T) {
+	service := &fakeGetProductService{err: domain.ErrResourceNotFound}
+	renderer := &fakeRenderer{}
+	handler := NewProductHandler(service, renderer)
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	req.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+
+	handler.GetProduct(rec, req, "missing")
+
+	if renderer.name != "error" {
+		t.Fatalf("rendered template = %q, want %q", renderer.name, "error")
+	}
+	if renderer.status != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", renderer.status, http.StatusNotFound)
+	}
+	page, ok := renderer.binding.(errorPageData)
+	if !ok {
+		t.Fatalf("binding type = %T, want errorPageData", renderer.binding)
+	}
+	if page.Description == "" {
+		t.Error("errorPageData.Description is empty, want the error detail for layout.html's meta description")
+	}
+}
+
+// TestHandleGetProductHTMLIgnoresAccept asserts that the dedicated
+// /{product_id}.html route always renders HTML regardless of Accept.
+func TestHandleGetProductHTMLIgnoresAccept(t *testing.T) {
+	service := &fakeGetProductService{product: domain.Product{ProductID: "111", Name: "Chunky Monkey"}}
+	renderer := &fakeRenderer{}
+	handler := NewProductHandler(service, renderer)
+
+	req := httptest.NewRequest(http.MethodGet, "/111.html", nil)
+	req.Header.Set("Accept", "application/json")
+	req = mux.SetURLVars(req, map[string]string{"product_id": "111"})
+	rec := httptest.NewRecorder()
+
+	handler.handleGetProductHTML()(rec, req)
+
+	if renderer.name != "product" {
+		t.Errorf("rendered template = %q, want %q", renderer.name, "product")
+	}
+}
+
+func TestAcceptsHTML(t *testing.T) {
+	cases := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"text/html", true},
+		{"text/html, application/json", true},
+		{"application/json, text/html", false},
+		{"application/json;q=0.9, text/html;q=0.1", false},
+		{"application/json;q=0.1, text/html;q=0.9", true},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/111", nil)
+		req.Header.Set("Accept", c.accept)
+		if got := acceptsHTML(req); got != c.want {
+			t.Errorf("acceptsHTML(%q) = %v, want %v", c.accept, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Package render provides the HTML rendering used by ProductHandler to
+// serve human- and crawler-facing product pages alongside the JSON API.
+package render
+
+import (
+	"io"
+
+	"github.com/unrolled/render"
+)
+
+// Renderer renders a named template with a binding. It is kept as a small
+// interface, rather than exposing *render.Render directly, so handlers can
+// be tested against a fake without spinning up a template directory.
+type Renderer interface {
+	HTML(w io.Writer, status int, name string, binding interface{}) error
+}
+
+// New builds the default Renderer, loading templates from dir and wrapping
+// each one in the shared "layout" template.
+func New(dir string) Renderer {
+	r := render.New(render.Options{
+		Directory:     dir,
+		Layout:        "layout",
+		Extensions:    []string{".html"},
+		IsDevelopment: false,
+	})
+	return unrolledRenderer{r: r}
+}
+
+type unrolledRenderer struct {
+	r *render.Render
+}
+
+func (u unrolledRenderer) HTML(w io.Writer, status int, name string, binding interface{}) error {
+	return u.r.HTML(w, status, name, binding)
+}
@@ -0,0 +1,55 @@
+package http
+
+import "testing"
+
+// TestValidateAgainstSchemaRejectsNonURIImage asserts that image_closed is
+// still rejected for a non-URI value, now that it's enforced via pattern
+// instead of the unenforced bare format: uri keyword.
+func TestValidateAgainstSchemaRejectsNonURIImage(t *testing.T) {
+	row := []byte(`{
+		"productId": "111",
+		"name": "Chunky Monkey",
+		"description": "banana ice cream",
+		"allergy_info": "walnuts",
+		"dietary_certifications": "none",
+		"image_closed": "not-a-url"
+	}`)
+
+	if err := validateAgainstSchema("productCreateRequest", row); err == nil {
+		t.Error("expected a non-URI image_closed to be rejected, got nil error")
+	}
+}
+
+// TestValidateAgainstSchemaRejectsNonASCIIName asserts that name is rejected
+// when it contains non-ASCII characters, restoring the ascii constraint the
+// old validate:"ascii" tag enforced.
+func TestValidateAgainstSchemaRejectsNonASCIIName(t *testing.T) {
+	row := []byte(`{
+		"productId": "111",
+		"name": "Chunky Monkey 🐒",
+		"description": "banana ice cream",
+		"allergy_info": "walnuts",
+		"dietary_certifications": "none"
+	}`)
+
+	if err := validateAgainstSchema("productCreateRequest", row); err == nil {
+		t.Error("expected a non-ASCII name to be rejected, got nil error")
+	}
+}
+
+// TestValidateAgainstSchemaAcceptsValidRow asserts the happy path still
+// passes once the stricter name/image_closed patterns are in place.
+func TestValidateAgainstSchemaAcceptsValidRow(t *testing.T) {
+	row := []byte(`{
+		"productId": "111",
+		"name": "Chunky Monkey",
+		"description": "banana ice cream",
+		"allergy_info": "walnuts",
+		"dietary_certifications": "none",
+		"image_closed": "https://example.com/chunky-monkey-closed.png"
+	}`)
+
+	if err := validateAgainstSchema("productCreateRequest", row); err != nil {
+		t.Errorf("expected a valid row to pass, got: %v", err)
+	}
+}